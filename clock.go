@@ -0,0 +1,35 @@
+package ratelimiter
+
+import "time"
+
+// Clock abstracts time.Now so that tests can advance time deterministically
+// instead of sleeping for real.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// FakeClock is a Clock whose time only moves when Advance is called,
+// letting tests exercise TTL expiry without sleeping.
+type FakeClock struct {
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock starting at the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,21 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Unix(0, 0)
+	clk := NewFakeClock(start)
+
+	if !clk.Now().Equal(start) {
+		t.Fatalf("expected a fresh FakeClock to report its start time")
+	}
+
+	clk.Advance(5 * time.Second)
+
+	if want := start.Add(5 * time.Second); !clk.Now().Equal(want) {
+		t.Fatalf("expected clock to report [%s] after advancing, got [%s]", want, clk.Now())
+	}
+}
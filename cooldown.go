@@ -0,0 +1,56 @@
+package ratelimiter
+
+import "time"
+
+// cooldownEntry tracks a key's balance under WithCooldown: it starts at
+// maxCount and is decremented on every Incr, clamped at -minBalance, and
+// only reset back to maxCount once period elapses with no attempts at all.
+type cooldownEntry struct {
+	key       string
+	balance   int
+	expiresAt time.Time
+}
+
+// incrWithCooldown implements Incr's negative-balance behavior for
+// RateLimiters constructed with WithCooldown.
+func (rl *RateLimiter) incrWithCooldown(key string, maxCount int) (int, bool) {
+	rl.cooldownMu.Lock()
+	defer rl.cooldownMu.Unlock()
+
+	now := rl.clock.Now()
+
+	var en *cooldownEntry
+	if el, ok := rl.cooldownCache[key]; ok {
+		rl.cooldownLL.MoveToFront(el)
+		en = el.Value.(*cooldownEntry)
+		if rl.period > 0 && !now.Before(en.expiresAt) {
+			en.balance = maxCount
+		}
+	} else {
+		en = &cooldownEntry{key: key, balance: maxCount}
+		el := rl.cooldownLL.PushFront(en)
+		rl.cooldownCache[key] = el
+
+		if rl.maxEntries > 0 && rl.cooldownLL.Len() > rl.maxEntries {
+			rl.removeOldestCooldown()
+		}
+	}
+
+	en.balance--
+	if en.balance < -rl.cooldownMinBalance {
+		en.balance = -rl.cooldownMinBalance
+	}
+	if rl.period > 0 {
+		en.expiresAt = now.Add(rl.period)
+	}
+
+	return en.balance, en.balance >= 0
+}
+
+func (rl *RateLimiter) removeOldestCooldown() {
+	if el := rl.cooldownLL.Back(); el != nil {
+		rl.cooldownLL.Remove(el)
+		en := el.Value.(*cooldownEntry)
+		delete(rl.cooldownCache, en.key)
+	}
+}
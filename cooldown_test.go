@@ -0,0 +1,72 @@
+package ratelimiter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithCooldownAccumulatesNegativeBalance(t *testing.T) {
+	clk := NewFakeClock(time.Unix(0, 0))
+	rl, err := NewWithClock(100, 10*time.Second, clk, WithCooldown(5))
+	if err != nil {
+		t.Fatalf("Cache should have been created OK")
+	}
+
+	key := "foo"
+	maxCount := 3
+
+	for i := 0; i < maxCount; i++ {
+		if _, allowed := rl.Incr(key, maxCount); !allowed {
+			t.Fatalf("expected attempt [%d] within maxCount to be allowed", i+1)
+		}
+	}
+
+	// keep hammering past maxCount; the balance should go negative but never
+	// past -minBalance.
+	var balance int
+	var allowed bool
+	for i := 0; i < 10; i++ {
+		balance, allowed = rl.Incr(key, maxCount)
+		if allowed {
+			t.Fatalf("expected attempt beyond maxCount to be denied")
+		}
+	}
+
+	if balance != -5 {
+		t.Fatalf("expected balance to clamp at [-5], got [%d]", balance)
+	}
+
+	// advancing less than period should still refuse, since each attempt
+	// above refreshed the TTL.
+	clk.Advance(5 * time.Second)
+	if _, allowed := rl.Incr(key, maxCount); allowed {
+		t.Fatalf("expected key to still be cooling down before a full quiet period has passed")
+	}
+
+	// now stay quiet for a full period.
+	clk.Advance(10 * time.Second)
+	if _, allowed := rl.Incr(key, maxCount); !allowed {
+		t.Fatalf("expected key to recover once a full period has passed with no attempts")
+	}
+}
+
+// NewWithStore's cooldown-tracking cache must stay bounded by its own
+// maxEntries even when the backing Store has no comparable notion of
+// capacity.
+func TestWithCooldownIsBoundedUnderNewWithStore(t *testing.T) {
+	maxEntriesForCooldownCache := 10
+	rl, err := NewWithStore(maxEntriesForCooldownCache, NewInMemoryStore(1000000, realClock{}), 10*time.Second, WithCooldown(5))
+	if err != nil {
+		t.Fatalf("Cache should have been created OK")
+	}
+
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("key_%d", i)
+		rl.Incr(key, 1)
+	}
+
+	if got := len(rl.cooldownCache); got > maxEntriesForCooldownCache {
+		t.Fatalf("expected cooldownCache to stay bounded at [%d], got [%d]", maxEntriesForCooldownCache, got)
+	}
+}
@@ -0,0 +1,92 @@
+package ratelimiter
+
+import "time"
+
+// failureEntry tracks a key that has failed at least once. Keys that only
+// ever Commit never get one of these, so well-behaved callers cost nothing
+// in the LRU.
+type failureEntry struct {
+	key           interface{}
+	failures      int
+	cooldownUntil time.Time
+}
+
+// Reserve reports whether key is currently allowed to attempt its
+// operation, i.e. it is not serving a cooldown from prior failures. Keys
+// that have never failed are always reserveable.
+func (rl *RateLimiter) Reserve(key interface{}) bool {
+	rl.failureMu.Lock()
+	defer rl.failureMu.Unlock()
+
+	el, ok := rl.failureCache[key]
+	if !ok {
+		return true
+	}
+
+	fe := el.Value.(*failureEntry)
+	return !rl.clock.Now().Before(fe.cooldownUntil)
+}
+
+// Commit records that key's operation succeeded. Successful keys never
+// consume failure-tracking memory, so there is nothing to update here.
+func (rl *RateLimiter) Commit(key interface{}) {}
+
+// Fail records that key's operation failed, returning the key's new
+// failure count and whether it is still reserveable. Once failures exceeds
+// maxFailures, key enters a cooldown during which Reserve returns false for
+// cooldown; each additional Fail while cooling down extends the cooldown
+// again from now.
+func (rl *RateLimiter) Fail(key interface{}, maxFailures int, cooldown time.Duration) (int, bool) {
+	rl.failureMu.Lock()
+	defer rl.failureMu.Unlock()
+
+	now := rl.clock.Now()
+
+	var fe *failureEntry
+	if el, ok := rl.failureCache[key]; ok {
+		rl.failureLL.MoveToFront(el)
+		fe = el.Value.(*failureEntry)
+	} else {
+		fe = &failureEntry{key: key}
+		el := rl.failureLL.PushFront(fe)
+		rl.failureCache[key] = el
+
+		if rl.maxEntries > 0 && rl.failureLL.Len() > rl.maxEntries {
+			rl.removeOldestFailure()
+		}
+	}
+
+	fe.failures++
+	if fe.failures > maxFailures {
+		fe.cooldownUntil = now.Add(cooldown)
+	}
+
+	return fe.failures, !now.Before(fe.cooldownUntil)
+}
+
+// Cooldown reports how long key must remain quiet before Reserve will
+// allow it again. It is zero for keys that are not currently cooling down.
+func (rl *RateLimiter) Cooldown(key interface{}) time.Duration {
+	rl.failureMu.Lock()
+	defer rl.failureMu.Unlock()
+
+	el, ok := rl.failureCache[key]
+	if !ok {
+		return 0
+	}
+
+	fe := el.Value.(*failureEntry)
+	remaining := fe.cooldownUntil.Sub(rl.clock.Now())
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (rl *RateLimiter) removeOldestFailure() {
+	if el := rl.failureLL.Back(); el != nil {
+		rl.failureLL.Remove(el)
+		fe := el.Value.(*failureEntry)
+		delete(rl.failureCache, fe.key)
+	}
+}
@@ -0,0 +1,82 @@
+package ratelimiter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReserveAllowsKeysThatHaveNeverFailed(t *testing.T) {
+	rl, _ := New(100, 10*time.Second)
+
+	if !rl.Reserve("foo") {
+		t.Fatalf("expected a key with no failures to be reserveable")
+	}
+	if rl.Len() != 0 || len(rl.failureCache) != 0 {
+		t.Fatalf("expected a successful-only key to consume no LRU memory, got len [%d] and failure entries [%d]", rl.Len(), len(rl.failureCache))
+	}
+}
+
+func TestFailEntersCooldownAfterThreshold(t *testing.T) {
+	clk := NewFakeClock(time.Unix(0, 0))
+	rl, _ := NewWithClock(100, 10*time.Second, clk)
+
+	key := "foo"
+	maxFailures := 3
+	cooldown := 5 * time.Second
+
+	for i := 0; i < maxFailures; i++ {
+		if _, reserveable := rl.Fail(key, maxFailures, cooldown); !reserveable {
+			t.Fatalf("expected failure [%d] to stay under threshold", i+1)
+		}
+	}
+
+	if _, reserveable := rl.Fail(key, maxFailures, cooldown); reserveable {
+		t.Fatalf("expected exceeding maxFailures to trigger a cooldown")
+	}
+	if rl.Reserve(key) {
+		t.Fatalf("expected key to be denied while cooling down")
+	}
+	if wait := rl.Cooldown(key); wait <= 0 {
+		t.Fatalf("expected a positive cooldown, got %s", wait)
+	}
+
+	clk.Advance(cooldown)
+
+	if !rl.Reserve(key) {
+		t.Fatalf("expected key to be reserveable again once the cooldown elapses")
+	}
+	if wait := rl.Cooldown(key); wait != 0 {
+		t.Fatalf("expected cooldown to be zero once elapsed, got %s", wait)
+	}
+}
+
+func TestCommitIsANoOp(t *testing.T) {
+	rl, _ := New(100, 10*time.Second)
+
+	rl.Commit("foo")
+
+	if rl.Len() != 0 || len(rl.failureCache) != 0 {
+		t.Fatalf("expected Commit to not create any tracked entries, got len [%d] and failure entries [%d]", rl.Len(), len(rl.failureCache))
+	}
+}
+
+// NewWithStore's failure-tracking cache must stay bounded by its own
+// maxEntries even when the backing Store (e.g. a RedisStore) has no
+// comparable notion of capacity.
+func TestFailIsBoundedUnderNewWithStore(t *testing.T) {
+	maxEntriesForFailureCache := 10
+	rl, err := NewWithStore(maxEntriesForFailureCache, NewInMemoryStore(1000000, realClock{}), 10*time.Second)
+	if err != nil {
+		t.Fatalf("Cache should have been created OK")
+	}
+
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("key_%d", i)
+		rl.Fail(key, 1, time.Second)
+	}
+
+	if got := len(rl.failureCache); got > maxEntriesForFailureCache {
+		t.Fatalf("expected failureCache to stay bounded at [%d], got [%d]", maxEntriesForFailureCache, got)
+	}
+}
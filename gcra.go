@@ -0,0 +1,173 @@
+package ratelimiter
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// GCRA is a keyed rate limiter implementing the Generic Cell Rate
+// Algorithm. Each key tracks a single theoretical arrival time (TAT); a
+// request is allowed as long as TAT is no further than burstTolerance
+// ahead of now, which makes GCRA equivalent to a token bucket while only
+// needing to store one timestamp per key instead of a token count.
+type GCRA struct {
+	maxEntries       int
+	emissionInterval time.Duration
+	burstTolerance   time.Duration
+	clock            Clock
+
+	// OnEvicted optionally fires whenever a key is evicted, either because
+	// the cache hit maxEntries or via an explicit Remove.
+	OnEvicted func(key interface{}, value interface{})
+
+	mu    sync.Mutex
+	ll    *list.List
+	cache map[interface{}]*list.Element
+}
+
+type gcraEntry struct {
+	key interface{}
+	tat time.Time
+}
+
+// NewGCRA creates a GCRA limiter that tracks at most maxEntries keys,
+// allowing quota requests per period. A key may use its whole quota in one
+// burst; after that, requests are admitted one every period/quota.
+func NewGCRA(maxEntries int, period time.Duration, quota int) (*GCRA, error) {
+	return NewGCRAWithClock(maxEntries, period, quota, realClock{})
+}
+
+// NewGCRAWithClock is like NewGCRA but lets callers supply their own Clock,
+// which is primarily useful for tests that need to advance time
+// deterministically instead of sleeping.
+func NewGCRAWithClock(maxEntries int, period time.Duration, quota int, clk Clock) (*GCRA, error) {
+	if maxEntries <= 0 {
+		return nil, errors.New("ratelimiter: maxEntries must be greater than zero")
+	}
+	if quota <= 0 {
+		return nil, errors.New("ratelimiter: quota must be greater than zero")
+	}
+	if period <= 0 {
+		return nil, errors.New("ratelimiter: period must be greater than zero")
+	}
+
+	emissionInterval := period / time.Duration(quota)
+	return &GCRA{
+		maxEntries:       maxEntries,
+		emissionInterval: emissionInterval,
+		burstTolerance:   emissionInterval * time.Duration(quota-1),
+		clock:            clk,
+		ll:               list.New(),
+		cache:            make(map[interface{}]*list.Element),
+	}, nil
+}
+
+// Incr reports whether key is allowed through right now. On success, the
+// key's theoretical arrival time is advanced by emissionInterval; on
+// failure it is left untouched so a denied request doesn't push the key
+// further into cooldown.
+func (g *GCRA) Incr(key interface{}) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.clock.Now()
+	en := g.entry(key, now)
+
+	tat := en.tat
+	if tat.Before(now) {
+		tat = now
+	}
+	newTAT := tat.Add(g.emissionInterval)
+
+	if newTAT.Sub(now) > g.burstTolerance+g.emissionInterval {
+		return en.tat, false
+	}
+
+	en.tat = newTAT
+	return en.tat, true
+}
+
+// Get returns the current theoretical arrival time for key.
+func (g *GCRA) Get(key interface{}) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	el, ok := g.cache[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	g.ll.MoveToFront(el)
+	return el.Value.(*gcraEntry).tat, true
+}
+
+// Remove evicts key.
+func (g *GCRA) Remove(key interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if el, ok := g.cache[key]; ok {
+		g.removeElement(el)
+	}
+}
+
+// Len reports the number of keys currently tracked.
+func (g *GCRA) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.ll.Len()
+}
+
+// RetryAfter reports how long a caller should wait before key will be
+// allowed again. It is zero if key would be allowed right now.
+func (g *GCRA) RetryAfter(key interface{}) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	el, ok := g.cache[key]
+	if !ok {
+		return 0
+	}
+	en := el.Value.(*gcraEntry)
+	now := g.clock.Now()
+
+	wait := en.tat.Sub(now) - g.burstTolerance
+	if wait <= 0 {
+		return 0
+	}
+	return wait
+}
+
+func (g *GCRA) entry(key interface{}, now time.Time) *gcraEntry {
+	if el, ok := g.cache[key]; ok {
+		g.ll.MoveToFront(el)
+		return el.Value.(*gcraEntry)
+	}
+
+	en := &gcraEntry{key: key, tat: now}
+	el := g.ll.PushFront(en)
+	g.cache[key] = el
+
+	if g.maxEntries > 0 && g.ll.Len() > g.maxEntries {
+		g.removeOldest()
+	}
+
+	return en
+}
+
+func (g *GCRA) removeOldest() {
+	if el := g.ll.Back(); el != nil {
+		g.removeElement(el)
+	}
+}
+
+func (g *GCRA) removeElement(el *list.Element) {
+	g.ll.Remove(el)
+	en := el.Value.(*gcraEntry)
+	delete(g.cache, en.key)
+	if g.OnEvicted != nil {
+		g.OnEvicted(en.key, en.tat)
+	}
+}
@@ -0,0 +1,81 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGCRAEmptyArgsError(t *testing.T) {
+	if _, err := NewGCRA(0, time.Second, 1); err == nil {
+		t.Fatalf("expected a maxentry size of 0 would fail Cache creation")
+	}
+	if _, err := NewGCRA(10, time.Second, 0); err == nil {
+		t.Fatalf("expected a quota of 0 would fail Cache creation")
+	}
+	if _, err := NewGCRA(10, 0, 1); err == nil {
+		t.Fatalf("expected a period of 0 would fail Cache creation")
+	}
+}
+
+func TestGCRAAllowsQuotaThenDenies(t *testing.T) {
+	g, err := NewGCRA(100, time.Second, 5)
+	if err != nil {
+		t.Fatalf("Cache should have been created OK")
+	}
+
+	key := "foo"
+	for i := 0; i < 5; i++ {
+		if _, allowed := g.Incr(key); !allowed {
+			t.Fatalf("expected draw [%d] within quota to be allowed", i)
+		}
+	}
+
+	if _, allowed := g.Incr(key); allowed {
+		t.Fatalf("expected draw beyond quota to be denied")
+	}
+}
+
+func TestGCRARecoversAfterWaiting(t *testing.T) {
+	clk := NewFakeClock(time.Unix(0, 0))
+	g, _ := NewGCRAWithClock(100, time.Second, 1, clk)
+
+	key := "foo"
+	if _, allowed := g.Incr(key); !allowed {
+		t.Fatalf("expected first draw to be allowed")
+	}
+	if _, allowed := g.Incr(key); allowed {
+		t.Fatalf("expected second immediate draw to be denied")
+	}
+
+	clk.Advance(time.Second)
+
+	if _, allowed := g.Incr(key); !allowed {
+		t.Fatalf("expected draw after the emission interval to be allowed")
+	}
+}
+
+func TestGCRARetryAfter(t *testing.T) {
+	g, _ := NewGCRA(100, time.Second, 1)
+
+	key := "foo"
+	g.Incr(key)
+	g.Incr(key)
+
+	if wait := g.RetryAfter(key); wait <= 0 {
+		t.Fatalf("expected a positive retry-after once the quota is exhausted, got %s", wait)
+	}
+}
+
+func TestGCRAMaxItemsInCache(t *testing.T) {
+	maxItemsInCache := 10
+	g, _ := NewGCRA(maxItemsInCache, time.Second, 1)
+
+	for i := 0; i < 15; i++ {
+		key := i
+		g.Incr(key)
+	}
+
+	if g.Len() > maxItemsInCache {
+		t.Fatalf("expected to only have [%d] items in cache, actually got [%d]", maxItemsInCache, g.Len())
+	}
+}
@@ -0,0 +1,125 @@
+package ratelimiter
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is the default Store: a single process's counters kept in
+// an LRU-bounded map so that tracking unboundedly many keys can't grow
+// memory without limit.
+type InMemoryStore struct {
+	maxEntries int
+	clock      Clock
+
+	// OnEvicted optionally fires whenever a key is evicted from the LRU,
+	// either because the cache hit maxEntries or via an explicit Remove.
+	OnEvicted func(key string, value interface{})
+
+	mu    sync.Mutex
+	ll    *list.List
+	cache map[string]*list.Element
+}
+
+type storeEntry struct {
+	key     string
+	count   int
+	resetAt time.Time
+}
+
+// NewInMemoryStore creates an InMemoryStore that tracks at most maxEntries
+// keys, using clk to read the current time.
+func NewInMemoryStore(maxEntries int, clk Clock) *InMemoryStore {
+	return &InMemoryStore{
+		maxEntries: maxEntries,
+		clock:      clk,
+		ll:         list.New(),
+		cache:      make(map[string]*list.Element),
+	}
+}
+
+// Incr implements Store.
+func (s *InMemoryStore) Incr(key string, ttl time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	if el, ok := s.cache[key]; ok {
+		s.ll.MoveToFront(el)
+		en := el.Value.(*storeEntry)
+		if ttl > 0 && !now.Before(en.resetAt) {
+			en.count = 0
+			en.resetAt = now.Add(ttl)
+		}
+		en.count++
+		return en.count, nil
+	}
+
+	en := &storeEntry{key: key, count: 1}
+	if ttl > 0 {
+		en.resetAt = now.Add(ttl)
+	}
+	el := s.ll.PushFront(en)
+	s.cache[key] = el
+
+	if s.maxEntries > 0 && s.ll.Len() > s.maxEntries {
+		s.removeOldest()
+	}
+
+	return en.count, nil
+}
+
+// Get implements Store.
+func (s *InMemoryStore) Get(key string) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.cache[key]
+	if !ok {
+		return 0, false, nil
+	}
+
+	en := el.Value.(*storeEntry)
+	if !en.resetAt.IsZero() && !s.clock.Now().Before(en.resetAt) {
+		return 0, false, nil
+	}
+
+	s.ll.MoveToFront(el)
+	return en.count, true, nil
+}
+
+// Remove implements Store.
+func (s *InMemoryStore) Remove(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.cache[key]; ok {
+		s.removeElement(el)
+	}
+	return nil
+}
+
+// Len implements Store.
+func (s *InMemoryStore) Len() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ll.Len(), nil
+}
+
+func (s *InMemoryStore) removeOldest() {
+	if el := s.ll.Back(); el != nil {
+		s.removeElement(el)
+	}
+}
+
+func (s *InMemoryStore) removeElement(el *list.Element) {
+	s.ll.Remove(el)
+	en := el.Value.(*storeEntry)
+	delete(s.cache, en.key)
+	if s.OnEvicted != nil {
+		s.OnEvicted(en.key, en.count)
+	}
+}
@@ -0,0 +1,160 @@
+// Package ratelimiter provides keyed rate limiters. By default their state
+// lives in this process in an LRU cache, so that the number of tracked keys
+// (e.g. one per client IP) is bounded no matter how many distinct keys are
+// seen; RateLimiter can instead keep its counters in a shared Store such as
+// a RedisStore.
+package ratelimiter
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a fixed-window counter keyed by string. Each key is
+// allowed up to maxCount increments within period before Incr starts
+// reporting it as rate limited; the window resets the first time Incr (or
+// Get) observes that period has elapsed since the key's count was last
+// reset.
+//
+// Counters are kept in a Store, which defaults to an LRU-bounded
+// InMemoryStore but can be swapped for e.g. a RedisStore so multiple
+// replicas share the same counts.
+type RateLimiter struct {
+	maxEntries int
+	period     time.Duration
+	clock      Clock
+	store      Store
+
+	// OnEvicted optionally fires whenever a key is evicted from the
+	// underlying Store. It only fires for stores that support eviction
+	// notifications, which InMemoryStore does and RedisStore does not.
+	OnEvicted func(key string, value interface{})
+
+	// failureMu, failureLL and failureCache back the Reserve/Commit/Fail
+	// failure-only mode (see failuremode.go). They stay empty for callers
+	// who only use Incr.
+	failureMu    sync.Mutex
+	failureLL    *list.List
+	failureCache map[interface{}]*list.Element
+
+	// cooldownMinBalance, cooldownMu, cooldownLL and cooldownCache back the
+	// WithCooldown negative-balance mode (see cooldown.go). They are unused
+	// unless WithCooldown was passed to New.
+	cooldownEnabled    bool
+	cooldownMinBalance int
+	cooldownMu         sync.Mutex
+	cooldownLL         *list.List
+	cooldownCache      map[string]*list.Element
+}
+
+// New creates a RateLimiter backed by an InMemoryStore that tracks at most
+// maxEntries keys, resetting each key's count every period. A period of 0
+// disables the reset: once a key is rate limited it stays that way for the
+// life of the RateLimiter.
+func New(maxEntries int, period time.Duration, opts ...Option) (*RateLimiter, error) {
+	return NewWithClock(maxEntries, period, realClock{}, opts...)
+}
+
+// NewWithClock is like New but lets callers supply their own Clock, which
+// is primarily useful for tests that need to advance time deterministically
+// instead of sleeping.
+func NewWithClock(maxEntries int, period time.Duration, clk Clock, opts ...Option) (*RateLimiter, error) {
+	if maxEntries <= 0 {
+		return nil, errors.New("ratelimiter: maxEntries must be greater than zero")
+	}
+
+	rl := newRateLimiter(maxEntries, NewInMemoryStore(maxEntries, clk), period, opts...)
+	rl.clock = clk
+	return rl, nil
+}
+
+// NewWithStore creates a RateLimiter backed by store, so callers can pick
+// an InMemoryStore for a single instance or a RedisStore to share counters
+// across replicas without changing any Incr/Get/Remove call sites. period
+// is passed to store.Incr as every key's ttl.
+//
+// maxEntries bounds the Reserve/Commit/Fail and WithCooldown side-tracking
+// caches (see failuremode.go and cooldown.go): those always live in this
+// process regardless of which Store backs Incr/Get/Remove, so they need
+// their own cap even when store is something unbounded like a RedisStore.
+func NewWithStore(maxEntries int, store Store, period time.Duration, opts ...Option) (*RateLimiter, error) {
+	if maxEntries <= 0 {
+		return nil, errors.New("ratelimiter: maxEntries must be greater than zero")
+	}
+
+	return newRateLimiter(maxEntries, store, period, opts...), nil
+}
+
+func newRateLimiter(maxEntries int, store Store, period time.Duration, opts ...Option) *RateLimiter {
+	rl := &RateLimiter{
+		maxEntries:   maxEntries,
+		period:       period,
+		clock:        realClock{},
+		store:        store,
+		failureLL:    list.New(),
+		failureCache: make(map[interface{}]*list.Element),
+	}
+
+	if ims, ok := store.(*InMemoryStore); ok {
+		ims.OnEvicted = func(key string, value interface{}) {
+			if rl.OnEvicted != nil {
+				rl.OnEvicted(key, value)
+			}
+		}
+	}
+
+	for _, opt := range opts {
+		opt(rl)
+	}
+
+	if rl.cooldownEnabled {
+		rl.cooldownLL = list.New()
+		rl.cooldownCache = make(map[string]*list.Element)
+	}
+
+	return rl
+}
+
+// Incr increments the count for key and reports whether that key is still
+// under maxCount, i.e. whether the caller should be allowed through. If
+// WithCooldown was passed to New, Incr instead follows the negative-balance
+// cooldown behavior documented on WithCooldown.
+func (rl *RateLimiter) Incr(key string, maxCount int) (int, bool) {
+	if rl.cooldownEnabled {
+		return rl.incrWithCooldown(key, maxCount)
+	}
+
+	count, err := rl.store.Incr(key, rl.period)
+	if err != nil {
+		return 0, false
+	}
+	return count, count <= maxCount
+}
+
+// Get returns the current count for key without incrementing it. The
+// second return value is false if key is not currently tracked, including
+// when its window has already expired.
+func (rl *RateLimiter) Get(key string) (int, bool) {
+	count, ok, err := rl.store.Get(key)
+	if err != nil {
+		return 0, false
+	}
+	return count, ok
+}
+
+// Remove evicts key from the underlying Store.
+func (rl *RateLimiter) Remove(key string) {
+	_ = rl.store.Remove(key)
+}
+
+// Len reports the number of keys currently tracked by the underlying
+// Store.
+func (rl *RateLimiter) Len() int {
+	n, err := rl.store.Len()
+	if err != nil {
+		return 0
+	}
+	return n
+}
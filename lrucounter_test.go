@@ -2,6 +2,8 @@ package ratelimiter
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -114,7 +116,8 @@ func TestRemove(t *testing.T) {
 // once you're rate limited you're done
 func TestRateLimitDoesntRemove(t *testing.T) {
 
-	rl, _ := New(100, 0)
+	clk := NewFakeClock(time.Unix(0, 0))
+	rl, _ := NewWithClock(100, 0, clk)
 
 	maxCount := 10
 	key := "foo"
@@ -136,8 +139,8 @@ func TestRateLimitDoesntRemove(t *testing.T) {
 		t.Fatalf("expected that if we went over [%d] increments ratelimit would be true, but was false", maxCount)
 	}
 
-	// sleep for 3 seconds and we should be OK again
-	time.Sleep(3 * time.Second)
+	// advance the clock and we should still be rate limited since period is 0
+	clk.Advance(3 * time.Second)
 	cnt, underRateLimit := rl.Incr(key, maxCount)
 
 	if underRateLimit {
@@ -149,7 +152,8 @@ func TestRateLimitDoesntRemove(t *testing.T) {
 // ensure that after n seconds our rate limit no longer applies
 func TestRateLimitGetsRemoved(t *testing.T) {
 
-	rl, _ := New(100, 2*time.Second)
+	clk := NewFakeClock(time.Unix(0, 0))
+	rl, _ := NewWithClock(100, 2*time.Second, clk)
 
 	maxCount := 10
 	key := "foo"
@@ -171,12 +175,12 @@ func TestRateLimitGetsRemoved(t *testing.T) {
 		t.Fatalf("expected that if we went over [%d] increments ratelimit would be true, but was false", maxCount)
 	}
 
-	// sleep for 3 seconds and we should be OK again
-	time.Sleep(3 * time.Second)
+	// advance the clock past the ttl and we should be OK again
+	clk.Advance(3 * time.Second)
 	cnt, underRateLimit := rl.Incr(key, maxCount)
 
 	if !underRateLimit {
-		t.Fatalf("expected that if we slept for a while to pass the ttl that we'd be ok again but our count was [%d]", cnt)
+		t.Fatalf("expected that if we advanced past the ttl that we'd be ok again but our count was [%d]", cnt)
 	}
 
 	for i := 0; i < 15; i++ {
@@ -189,11 +193,11 @@ func TestRateLimitGetsRemoved(t *testing.T) {
 		t.Fatalf("expected that if we went over [%d] increments ratelimit would be true, but was false", maxCount)
 	}
 
-	// sleep for 3 seconds and we should be OK again
-	time.Sleep(3 * time.Second)
+	// advance the clock past the ttl and we should be OK again
+	clk.Advance(3 * time.Second)
 	cnt, underRateLimit = rl.Incr(key, maxCount)
 	if !underRateLimit {
-		t.Fatalf("expected that if we slept for a while to pass the ttl that we'd be ok again but our count was [%d]", cnt)
+		t.Fatalf("expected that if we advanced past the ttl that we'd be ok again but our count was [%d]", cnt)
 	}
 
 }
@@ -203,9 +207,9 @@ func TestOnEvictedCallback(t *testing.T) {
 	keys := []string{"foo", "bar", "baz"}
 
 	// We will only allow max items of 2, but will incr 3, so the first one in "foo" will be evicted and we should be notified
-	callback := func(key interface{}, value interface{}) {
-		if key.(string) != keys[0] {
-			t.Fatalf("Expected %s to be purged and sent in callback, got %s instead", keys[0], key.(string))
+	callback := func(key string, value interface{}) {
+		if key != keys[0] {
+			t.Fatalf("Expected %s to be purged and sent in callback, got %s instead", keys[0], key)
 		}
 	}
 
@@ -256,3 +260,41 @@ func BenchmarkGet(b *testing.B) {
 		_, _ = rl.Get(key)
 	}
 }
+
+// many goroutines hammering the same key should never let more than
+// maxCount of them observe an "admitted" result.
+func TestConcurrentIncr(t *testing.T) {
+	rl, _ := New(100, 10*time.Second)
+
+	maxCount := 50
+	goroutines := 500
+	key := "foo"
+
+	var admitted int64
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			if _, underRateLimit := rl.Incr(key, maxCount); underRateLimit {
+				atomic.AddInt64(&admitted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if int(admitted) > maxCount {
+		t.Fatalf("expected at most [%d] goroutines to be admitted, got [%d]", maxCount, admitted)
+	}
+}
+
+func BenchmarkParallelIncr(b *testing.B) {
+	rl, _ := New(100, 2*time.Second)
+	maxCount := 10
+	key := "foo"
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rl.Incr(key, maxCount)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package ratelimiter
+
+// Option configures optional RateLimiter behavior at construction time.
+type Option func(*RateLimiter)
+
+// WithCooldown switches a RateLimiter from the default "reset at maxCount"
+// behavior to a passive back-off: once a key exceeds maxCount, each further
+// Incr keeps decrementing its balance (instead of just reporting denied)
+// down to -minBalance, and refreshes the key's TTL on every attempt. A key
+// only recovers once period elapses with no Incr calls at all, so a client
+// that keeps hammering after being denied only digs itself in deeper
+// instead of getting a free reset at the next window boundary.
+func WithCooldown(minBalance int) Option {
+	return func(rl *RateLimiter) {
+		rl.cooldownEnabled = true
+		rl.cooldownMinBalance = minBalance
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// incrWithTTLScript atomically increments key and, only on the increment
+// that creates it, sets its expiry, mirroring the reset-on-first-write
+// semantics InMemoryStore gives a brand new key.
+var incrWithTTLScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// RedisStore is a Store backed by Redis, so counters are shared across
+// every replica talking to the same Redis instance instead of being
+// process-local.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a RedisStore using client. Keys are namespaced
+// under prefix (e.g. "ratelimiter:") so the store doesn't collide with
+// other data living in the same Redis instance.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Incr implements Store using a Lua script so the increment and the expiry
+// it sets on a brand new key happen atomically.
+func (s *RedisStore) Incr(key string, ttl time.Duration) (int, error) {
+	ctx := context.Background()
+	count, err := incrWithTTLScript.Run(ctx, s.client, []string{s.prefixed(key)}, ttl.Milliseconds()).Int()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Get implements Store.
+func (s *RedisStore) Get(key string) (int, bool, error) {
+	ctx := context.Background()
+	count, err := s.client.Get(ctx, s.prefixed(key)).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
+
+// Remove implements Store.
+func (s *RedisStore) Remove(key string) error {
+	ctx := context.Background()
+	return s.client.Del(ctx, s.prefixed(key)).Err()
+}
+
+// Len implements Store by scanning for keys under prefix. This is O(n) in
+// the keyspace and intended for tests/diagnostics rather than hot paths.
+func (s *RedisStore) Len() (int, error) {
+	ctx := context.Background()
+	var count int
+	iter := s.client.Scan(ctx, 0, s.prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *RedisStore) prefixed(key string) string {
+	return s.prefix + key
+}
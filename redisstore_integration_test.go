@@ -0,0 +1,47 @@
+//go:build integration
+
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ory/dockertest/v3"
+)
+
+// TestMain spins up a throwaway Redis container via dockertest and
+// registers RedisStore into storeFactories so TestStoreContract exercises
+// it the same way it exercises InMemoryStore. Run with:
+//
+//	go test -tags=integration ./...
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		panic(fmt.Sprintf("could not connect to docker: %s", err))
+	}
+
+	resource, err := pool.Run("redis", "7-alpine", nil)
+	if err != nil {
+		panic(fmt.Sprintf("could not start redis container: %s", err))
+	}
+	defer pool.Purge(resource)
+
+	var client *redis.Client
+	if err := pool.Retry(func() error {
+		client = redis.NewClient(&redis.Options{
+			Addr: fmt.Sprintf("localhost:%s", resource.GetPort("6379/tcp")),
+		})
+		return client.Ping(context.Background()).Err()
+	}); err != nil {
+		panic(fmt.Sprintf("could not connect to redis container: %s", err))
+	}
+
+	storeFactories["RedisStore"] = func() Store {
+		return NewRedisStore(client, fmt.Sprintf("test:%d:", time.Now().UnixNano()))
+	}
+
+	m.Run()
+}
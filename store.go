@@ -0,0 +1,24 @@
+package ratelimiter
+
+import "time"
+
+// Store backs a RateLimiter's per-key counters. InMemoryStore is the
+// default, bounded by an LRU so memory stays flat for a single process;
+// RedisStore lets the same counters be shared across replicas behind a
+// load balancer.
+type Store interface {
+	// Incr increments key's counter, creating it with the given ttl if it
+	// doesn't already exist, and returns the updated count. ttl of 0 means
+	// the counter never expires on its own.
+	Incr(key string, ttl time.Duration) (int, error)
+
+	// Get returns key's current count without incrementing it. The second
+	// return value is false if key is not currently tracked.
+	Get(key string) (int, bool, error)
+
+	// Remove evicts key.
+	Remove(key string) error
+
+	// Len reports how many keys are currently tracked.
+	Len() (int, error)
+}
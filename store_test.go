@@ -0,0 +1,70 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+// storeFactories lists every Store implementation that must satisfy the
+// contract below. Implementations that need a live backend (e.g. Redis)
+// append themselves here from their own build-tagged _test.go file (see
+// redisstore_integration_test.go, built with -tags=integration), so this
+// file only ever references InMemoryStore directly.
+var storeFactories = map[string]func() Store{
+	"InMemoryStore": func() Store {
+		return NewInMemoryStore(100, realClock{})
+	},
+}
+
+func TestStoreContract(t *testing.T) {
+	for name, newStore := range storeFactories {
+		t.Run(name, func(t *testing.T) {
+			t.Run("IncrCreatesAndIncrements", func(t *testing.T) {
+				s := newStore()
+				if count, err := s.Incr("foo", 10*time.Second); err != nil || count != 1 {
+					t.Fatalf("expected first Incr to return [1, nil], got [%d, %v]", count, err)
+				}
+				if count, err := s.Incr("foo", 10*time.Second); err != nil || count != 2 {
+					t.Fatalf("expected second Incr to return [2, nil], got [%d, %v]", count, err)
+				}
+			})
+
+			t.Run("GetReflectsIncr", func(t *testing.T) {
+				s := newStore()
+				s.Incr("foo", 10*time.Second)
+
+				count, ok, err := s.Get("foo")
+				if err != nil || !ok || count != 1 {
+					t.Fatalf("expected Get to return [1, true, nil], got [%d, %t, %v]", count, ok, err)
+				}
+			})
+
+			t.Run("GetMissingKey", func(t *testing.T) {
+				s := newStore()
+				if _, ok, err := s.Get("missing"); err != nil || ok {
+					t.Fatalf("expected Get of a missing key to return [_, false, nil], got [_, %t, %v]", ok, err)
+				}
+			})
+
+			t.Run("Remove", func(t *testing.T) {
+				s := newStore()
+				s.Incr("foo", 10*time.Second)
+				if err := s.Remove("foo"); err != nil {
+					t.Fatalf("expected Remove to succeed, got %v", err)
+				}
+				if _, ok, _ := s.Get("foo"); ok {
+					t.Fatalf("expected key to be gone after Remove")
+				}
+			})
+
+			t.Run("Len", func(t *testing.T) {
+				s := newStore()
+				s.Incr("foo", 10*time.Second)
+				s.Incr("bar", 10*time.Second)
+				if n, err := s.Len(); err != nil || n != 2 {
+					t.Fatalf("expected Len to report [2, nil], got [%d, %v]", n, err)
+				}
+			})
+		})
+	}
+}
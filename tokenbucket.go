@@ -0,0 +1,180 @@
+package ratelimiter
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a keyed token-bucket rate limiter. Unlike RateLimiter's
+// fixed-window counter, tokens refill continuously at rate per second up to
+// burst, so callers see smooth throttling instead of a hard reset at the
+// window boundary.
+type TokenBucket struct {
+	maxEntries int
+	rate       float64
+	burst      int
+	clock      Clock
+
+	// OnEvicted optionally fires whenever a key is evicted, either because
+	// the cache hit maxEntries or via an explicit Remove.
+	OnEvicted func(key interface{}, value interface{})
+
+	mu    sync.Mutex
+	ll    *list.List
+	cache map[interface{}]*list.Element
+}
+
+type tokenBucketEntry struct {
+	key        interface{}
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewTokenBucket creates a TokenBucket that tracks at most maxEntries keys.
+// Each key refills at rate tokens per second up to a maximum of burst
+// tokens.
+func NewTokenBucket(maxEntries int, rate float64, burst int) (*TokenBucket, error) {
+	return NewTokenBucketWithClock(maxEntries, rate, burst, realClock{})
+}
+
+// NewTokenBucketWithClock is like NewTokenBucket but lets callers supply
+// their own Clock, which is primarily useful for tests that need to
+// advance time deterministically instead of sleeping.
+func NewTokenBucketWithClock(maxEntries int, rate float64, burst int, clk Clock) (*TokenBucket, error) {
+	if maxEntries <= 0 {
+		return nil, errors.New("ratelimiter: maxEntries must be greater than zero")
+	}
+	if rate <= 0 {
+		return nil, errors.New("ratelimiter: rate must be greater than zero")
+	}
+	if burst <= 0 {
+		return nil, errors.New("ratelimiter: burst must be greater than zero")
+	}
+
+	return &TokenBucket{
+		maxEntries: maxEntries,
+		rate:       rate,
+		burst:      burst,
+		clock:      clk,
+		ll:         list.New(),
+		cache:      make(map[interface{}]*list.Element),
+	}, nil
+}
+
+// Incr draws one token for key, refilling it for the elapsed time since its
+// last access first. It returns the number of tokens remaining (rounded
+// down) and whether the draw succeeded.
+func (tb *TokenBucket) Incr(key interface{}) (int, bool) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := tb.clock.Now()
+	en := tb.entry(key, now)
+
+	tb.refill(en, now)
+
+	if en.tokens >= 1 {
+		en.tokens--
+		return int(en.tokens), true
+	}
+	return int(en.tokens), false
+}
+
+// Get returns the current token count for key (rounded down) without
+// drawing from it.
+func (tb *TokenBucket) Get(key interface{}) (int, bool) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	el, ok := tb.cache[key]
+	if !ok {
+		return 0, false
+	}
+	en := el.Value.(*tokenBucketEntry)
+	tb.refill(en, tb.clock.Now())
+	tb.ll.MoveToFront(el)
+	return int(en.tokens), true
+}
+
+// Remove evicts key from the bucket.
+func (tb *TokenBucket) Remove(key interface{}) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if el, ok := tb.cache[key]; ok {
+		tb.removeElement(el)
+	}
+}
+
+// Len reports the number of keys currently tracked.
+func (tb *TokenBucket) Len() int {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return tb.ll.Len()
+}
+
+// RetryAfter reports how long a caller should wait before key will have a
+// token available again. It is zero if a token is available right now.
+func (tb *TokenBucket) RetryAfter(key interface{}) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	el, ok := tb.cache[key]
+	if !ok {
+		return 0
+	}
+	en := el.Value.(*tokenBucketEntry)
+	tb.refill(en, tb.clock.Now())
+	if en.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - en.tokens) / tb.rate * float64(time.Second))
+}
+
+func (tb *TokenBucket) entry(key interface{}, now time.Time) *tokenBucketEntry {
+	if el, ok := tb.cache[key]; ok {
+		tb.ll.MoveToFront(el)
+		return el.Value.(*tokenBucketEntry)
+	}
+
+	en := &tokenBucketEntry{key: key, tokens: float64(tb.burst), lastRefill: now}
+	el := tb.ll.PushFront(en)
+	tb.cache[key] = el
+
+	if tb.maxEntries > 0 && tb.ll.Len() > tb.maxEntries {
+		tb.removeOldest()
+	}
+
+	return en
+}
+
+func (tb *TokenBucket) refill(en *tokenBucketEntry, now time.Time) {
+	elapsed := now.Sub(en.lastRefill).Seconds()
+	en.lastRefill = now
+	if elapsed <= 0 {
+		return
+	}
+
+	en.tokens += elapsed * tb.rate
+	if en.tokens > float64(tb.burst) {
+		en.tokens = float64(tb.burst)
+	}
+}
+
+func (tb *TokenBucket) removeOldest() {
+	if el := tb.ll.Back(); el != nil {
+		tb.removeElement(el)
+	}
+}
+
+func (tb *TokenBucket) removeElement(el *list.Element) {
+	tb.ll.Remove(el)
+	en := el.Value.(*tokenBucketEntry)
+	delete(tb.cache, en.key)
+	if tb.OnEvicted != nil {
+		tb.OnEvicted(en.key, int(en.tokens))
+	}
+}
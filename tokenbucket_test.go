@@ -0,0 +1,80 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketEmptyArgsError(t *testing.T) {
+	if _, err := NewTokenBucket(0, 1, 1); err == nil {
+		t.Fatalf("expected a maxentry size of 0 would fail Cache creation")
+	}
+	if _, err := NewTokenBucket(10, 0, 1); err == nil {
+		t.Fatalf("expected a rate of 0 would fail Cache creation")
+	}
+	if _, err := NewTokenBucket(10, 1, 0); err == nil {
+		t.Fatalf("expected a burst of 0 would fail Cache creation")
+	}
+}
+
+func TestTokenBucketAllowsBurst(t *testing.T) {
+	tb, err := NewTokenBucket(100, 1, 5)
+	if err != nil {
+		t.Fatalf("Cache should have been created OK")
+	}
+
+	key := "foo"
+	for i := 0; i < 5; i++ {
+		if _, allowed := tb.Incr(key); !allowed {
+			t.Fatalf("expected draw [%d] within burst to be allowed", i)
+		}
+	}
+
+	if _, allowed := tb.Incr(key); allowed {
+		t.Fatalf("expected draw beyond burst to be denied")
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	clk := NewFakeClock(time.Unix(0, 0))
+	tb, _ := NewTokenBucketWithClock(100, 1, 1, clk)
+
+	key := "foo"
+	if _, allowed := tb.Incr(key); !allowed {
+		t.Fatalf("expected first draw to be allowed")
+	}
+	if _, allowed := tb.Incr(key); allowed {
+		t.Fatalf("expected second immediate draw to be denied")
+	}
+
+	clk.Advance(time.Second)
+
+	if _, allowed := tb.Incr(key); !allowed {
+		t.Fatalf("expected draw after refill window to be allowed")
+	}
+}
+
+func TestTokenBucketRetryAfter(t *testing.T) {
+	tb, _ := NewTokenBucket(100, 1, 1)
+
+	key := "foo"
+	tb.Incr(key)
+
+	if wait := tb.RetryAfter(key); wait <= 0 {
+		t.Fatalf("expected a positive retry-after once the bucket is empty, got %s", wait)
+	}
+}
+
+func TestTokenBucketMaxItemsInCache(t *testing.T) {
+	maxItemsInCache := 10
+	tb, _ := NewTokenBucket(maxItemsInCache, 1, 1)
+
+	for i := 0; i < 15; i++ {
+		key := i
+		tb.Incr(key)
+	}
+
+	if tb.Len() > maxItemsInCache {
+		t.Fatalf("expected to only have [%d] items in cache, actually got [%d]", maxItemsInCache, tb.Len())
+	}
+}